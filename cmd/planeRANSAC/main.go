@@ -0,0 +1,106 @@
+// Command planeRANSAC finds the three most dominant planes in a point cloud
+// file via RANSAC, writing each plane's inliers (and the remainder) back out
+// alongside the input file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+	"github.com/yayosaleh/plane-ransac/pkg/ransac"
+)
+
+func main() {
+
+	start := time.Now() //for measuring run time
+
+	// Read command line arguments
+
+	// Program is executed using > planeRANSAC [--no-cache] filename confidence percentage eps
+
+	noCache := flag.Bool("no-cache", false, "skip the XYZ read cache (see pointcloud.ReadXYZCached)")
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) < 4 {
+		fmt.Println("Usage: planeRANSAC [--no-cache] filename confidence percentage eps")
+		return
+	}
+
+	filename := args[0]
+	confidence, confidenceErr := strconv.ParseFloat(args[1], 64)
+	percentage, percentageErr := strconv.ParseFloat(args[2], 64)
+	eps, epsErr := strconv.ParseFloat(args[3], 64)
+
+	if confidenceErr != nil {
+		fmt.Println("Error: could not parse confidence!")
+	}
+	if percentageErr != nil {
+		fmt.Println("Error: could not parse percentage!")
+	}
+	if epsErr != nil {
+		fmt.Println("Error: could not parse eps!")
+	}
+	if confidenceErr != nil || percentageErr != nil || epsErr != nil {
+		fmt.Println("Exiting!")
+		return
+	}
+
+	// Set up data required by plane RANSAC pipeline
+
+	var cloud []pointcloud.Point3D
+	if !*noCache && strings.EqualFold(path.Ext(filename), ".xyz") {
+		cloud = pointcloud.ReadXYZCached(filename) // skips re-parsing the ASCII file on repeated runs
+	} else {
+		var err error
+		cloud, err = pointcloud.Read(filename)
+		if err != nil {
+			if cloud == nil {
+				fmt.Println("Error occured, could not read points from file!")
+				panic(err)
+			}
+			// Some rows were skipped as malformed, but enough of the file
+			// parsed to proceed
+			fmt.Println(err)
+		}
+	}
+	numIterations := ransac.GetNumberOfIterations(confidence, percentage)
+	dominantPlaneInliers := []pointcloud.Point3D{}
+	filenameWithoutExtension := strings.TrimSuffix(filename, path.Ext(filename))
+
+	// Loop to find and save three most dominant planes
+
+	/*
+		Note:
+		Instead of manually running the program three times to find the three most dominant planes, and renaming the files,
+		I have automated this step with a for loop! Everytime this program is run, all three dominant planes are found and
+		saved to their respective files!
+	*/
+
+	for i := 0; i < 3; i++ {
+		bestSupport := ransac.Plane3DwSupport{}
+		ransac.Run(cloud, numIterations, eps, &bestSupport)                         //initiates pipeline to find the best supported (i.e., dominant) plane
+		refinedPlane := ransac.RefitIterative(cloud, bestSupport.Plane3D, eps)      //refit via total-least-squares so the saved plane isn't just the lucky triplet
+		dominantPlaneInliers = ransac.GetSupportingPoints(refinedPlane, cloud, eps) //extract points from cloud that support the refined plane
+		if err := pointcloud.Write(filenameWithoutExtension+"_p"+strconv.Itoa(i+1)+".xyz", dominantPlaneInliers); err != nil {
+			fmt.Println("Error occured, could not save points to file!")
+			panic(err)
+		}
+		cloud = ransac.RemovePlane(refinedPlane, cloud, eps) //remove dominant plane inliers from original point cloud -> refed into pipeline to find next most dominant plane
+	}
+	if err := pointcloud.Write(filenameWithoutExtension+"_p0.xyz", cloud); err != nil { //save remaining points in cloud when inliers of three most dominant planes are removed
+		fmt.Println("Error occured, could not save points to file!")
+		panic(err)
+	}
+
+	// Measure and print runtime
+
+	elapsed := time.Since(start)
+	fmt.Printf("Run time: %s \n", elapsed)
+
+}
@@ -0,0 +1,86 @@
+// Package planefit turns a RANSAC-fit plane and its inlier points into a
+// bounded polygon suitable for saving as a mesh face.
+package planefit
+
+import (
+	"math"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+	"github.com/yayosaleh/plane-ransac/pkg/ransac"
+)
+
+// point2D is a point in the 2D basis (u, v) of a plane
+type point2D struct {
+	X float64
+	Y float64
+}
+
+// planeBasis holds an origin on the plane and an orthonormal (u, v) basis
+// spanning it, used to project 3D inliers into 2D and back
+type planeBasis struct {
+	origin pointcloud.Point3D
+	u      pointcloud.Point3D
+	v      pointcloud.Point3D
+}
+
+func sub(a, b pointcloud.Point3D) pointcloud.Point3D {
+	return pointcloud.Point3D{X: a.X - b.X, Y: a.Y - b.Y, Z: a.Z - b.Z}
+}
+
+func dot(a, b pointcloud.Point3D) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+func cross(a, b pointcloud.Point3D) pointcloud.Point3D {
+	return pointcloud.Point3D{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
+
+func scale(a pointcloud.Point3D, s float64) pointcloud.Point3D {
+	return pointcloud.Point3D{X: a.X * s, Y: a.Y * s, Z: a.Z * s}
+}
+
+func normalize(a pointcloud.Point3D) pointcloud.Point3D {
+	length := math.Sqrt(dot(a, a))
+	return scale(a, 1/length)
+}
+
+// newPlaneBasis picks an orthonormal 2D basis (u, v) within plane, rooted at
+// the point on plane closest to the origin. u is obtained by Gram-Schmidt
+// orthogonalizing an axis not parallel to the plane's normal against the
+// normal, and v completes the right-handed basis via the cross product
+func newPlaneBasis(plane ransac.Plane3D) planeBasis {
+	normal := normalize(pointcloud.Point3D{X: plane.A, Y: plane.B, Z: plane.C})
+
+	// p0: point on the plane closest to the origin
+	origin := scale(normal, plane.D/dot(normal, normal))
+
+	// Pick whichever world axis is least parallel to normal
+	axis := pointcloud.Point3D{X: 1}
+	if math.Abs(normal.X) > 0.9 {
+		axis = pointcloud.Point3D{Y: 1}
+	}
+
+	u := normalize(sub(axis, scale(normal, dot(axis, normal)))) // Gram-Schmidt
+	v := cross(normal, u)
+
+	return planeBasis{origin: origin, u: u, v: v}
+}
+
+// project maps a 3D point onto the basis's (u, v) plane coordinates
+func (b planeBasis) project(p pointcloud.Point3D) point2D {
+	d := sub(p, b.origin)
+	return point2D{X: dot(d, b.u), Y: dot(d, b.v)}
+}
+
+// unproject maps a (u, v) plane coordinate back into 3D
+func (b planeBasis) unproject(p point2D) pointcloud.Point3D {
+	return pointcloud.Point3D{
+		X: b.origin.X + p.X*b.u.X + p.Y*b.v.X,
+		Y: b.origin.Y + p.X*b.u.Y + p.Y*b.v.Y,
+		Z: b.origin.Z + p.X*b.u.Z + p.Y*b.v.Z,
+	}
+}
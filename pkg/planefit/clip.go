@@ -0,0 +1,52 @@
+package planefit
+
+// inside reports whether p is on the inside (left, including boundary) of
+// the directed clip edge s->e, i.e. whether s,e,p form a non-clockwise turn
+func inside(s, e, p point2D) bool {
+	return cross2D(s, e, p) >= 0
+}
+
+// intersect returns the point where segment s->e crosses the (infinite)
+// line through the clip edge cs->ce
+func intersect(s, e, cs, ce point2D) point2D {
+	edgeDX, edgeDY := e.X-s.X, e.Y-s.Y
+	clipDX, clipDY := ce.X-cs.X, ce.Y-cs.Y
+
+	denom := edgeDX*clipDY - edgeDY*clipDX
+	t := ((cs.X-s.X)*clipDY - (cs.Y-s.Y)*clipDX) / denom
+
+	return point2D{X: s.X + t*edgeDX, Y: s.Y + t*edgeDY}
+}
+
+// clipPolygon clips subject (a simple polygon) against the convex clip
+// polygon using the Sutherland-Hodgman re-entrant algorithm. clip's vertices
+// must be wound counter-clockwise
+func clipPolygon(subject, clip []point2D) []point2D {
+	output := subject
+
+	for i := range clip {
+		if len(output) == 0 {
+			break
+		}
+		cs, ce := clip[i], clip[(i+1)%len(clip)]
+
+		input := output
+		output = make([]point2D, 0, len(input)+1)
+		for j := range input {
+			s := input[j]
+			e := input[(j+1)%len(input)]
+
+			sIn, eIn := inside(cs, ce, s), inside(cs, ce, e)
+			switch {
+			case sIn && eIn:
+				output = append(output, e)
+			case sIn && !eIn:
+				output = append(output, intersect(s, e, cs, ce))
+			case !sIn && eIn:
+				output = append(output, intersect(s, e, cs, ce), e)
+			}
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,50 @@
+package planefit
+
+import (
+	"math"
+	"testing"
+)
+
+// polygonArea returns a simple polygon's area via the shoelace formula
+func polygonArea(pts []point2D) float64 {
+	area := 0.0
+	n := len(pts)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		area += pts[i].X*pts[j].Y - pts[j].X*pts[i].Y
+	}
+	return math.Abs(area) / 2
+}
+
+func TestConvexHullDropsInteriorPoints(t *testing.T) {
+	points := []point2D{
+		{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, // square corners
+		{X: 2, Y: 2}, {X: 1, Y: 1}, {X: 3, Y: 3}, // interior points
+	}
+
+	hull := convexHull(points)
+	if len(hull) != 4 {
+		t.Fatalf("convexHull returned %d vertices, want 4: %v", len(hull), hull)
+	}
+	if got, want := polygonArea(hull), 16.0; got != want {
+		t.Errorf("hull area = %v, want %v", got, want)
+	}
+}
+
+func TestClipPolygonRoundTrip(t *testing.T) {
+	// The hull of a 4x4 square, clipped against a 2x2 window fully inside
+	// it, should yield exactly the clip window
+	square := convexHull([]point2D{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}})
+	window := []point2D{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 3}, {X: 1, Y: 3}}
+
+	clipped := clipPolygon(square, window)
+	if got, want := polygonArea(clipped), polygonArea(window); math.Abs(got-want) > 1e-9 {
+		t.Errorf("clipped area = %v, want %v (the clip window's area)", got, want)
+	}
+
+	// A clip window entirely outside the hull should leave nothing
+	outside := []point2D{{X: 10, Y: 10}, {X: 12, Y: 10}, {X: 12, Y: 12}, {X: 10, Y: 12}}
+	if empty := clipPolygon(square, outside); len(empty) != 0 {
+		t.Errorf("clipPolygon against a disjoint window returned %d vertices, want 0", len(empty))
+	}
+}
@@ -0,0 +1,48 @@
+package planefit
+
+import "sort"
+
+// cross2D returns the z-component of the cross product (O->A) x (O->B);
+// positive when O,A,B form a counter-clockwise turn
+func cross2D(o, a, b point2D) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// convexHull computes the 2D convex hull of points using Andrew's monotone
+// chain algorithm, returning the hull vertices in counter-clockwise order
+func convexHull(points []point2D) []point2D {
+	if len(points) < 3 {
+		return points
+	}
+
+	sorted := make([]point2D, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	build := func(pts []point2D) []point2D {
+		hull := make([]point2D, 0, len(pts))
+		for _, p := range pts {
+			for len(hull) >= 2 && cross2D(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1] // pop while the turn is not strictly counter-clockwise
+			}
+			hull = append(hull, p)
+		}
+		return hull
+	}
+
+	lower := build(sorted)
+
+	reversed := make([]point2D, len(sorted))
+	for i, p := range sorted {
+		reversed[len(sorted)-1-i] = p
+	}
+	upper := build(reversed)
+
+	// Both hulls include their shared endpoints; drop them before joining
+	return append(lower[:len(lower)-1], upper[:len(upper)-1]...)
+}
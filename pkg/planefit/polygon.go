@@ -0,0 +1,35 @@
+package planefit
+
+import (
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+	"github.com/yayosaleh/plane-ransac/pkg/ransac"
+)
+
+// ExtractBoundedPolygon turns a plane's inlier points into a finite polygon
+// region: it projects the inliers into the plane's own 2D basis, takes their
+// convex hull, clips that hull against boundingPolygon (a convex polygon
+// lying in the same plane, e.g. a room's wall bounds), and unprojects the
+// result back to 3D. boundingPolygon's vertices need not be inliers - only
+// coplanar with plane - and must be wound counter-clockwise when viewed from
+// the side the normal points to
+func ExtractBoundedPolygon(plane ransac.Plane3D, inliers []pointcloud.Point3D, boundingPolygon []pointcloud.Point3D) []pointcloud.Point3D {
+	basis := newPlaneBasis(plane)
+
+	projected := make([]point2D, len(inliers))
+	for i, p := range inliers {
+		projected[i] = basis.project(p)
+	}
+	hull := convexHull(projected)
+
+	clip := make([]point2D, len(boundingPolygon))
+	for i, p := range boundingPolygon {
+		clip[i] = basis.project(p)
+	}
+	clipped := clipPolygon(hull, clip)
+
+	polygon := make([]pointcloud.Point3D, len(clipped))
+	for i, p := range clipped {
+		polygon[i] = basis.unproject(p)
+	}
+	return polygon
+}
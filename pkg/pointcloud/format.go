@@ -0,0 +1,68 @@
+package pointcloud
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format reads and writes point clouds in a specific file format
+type Format interface {
+	Read(r io.Reader) ([]Point3D, error)
+	Write(w io.Writer, points []Point3D) error
+}
+
+// Formats registered by (lowercase, dot-prefixed) file extension, e.g. ".xyz"
+var formats = map[string]Format{}
+
+// RegisterFormat associates a Format with a file extension (e.g. ".ply");
+// intended to be called from the init() of a format's source file
+func RegisterFormat(ext string, format Format) {
+	formats[ext] = format
+}
+
+// FormatForExtension returns the Format registered for the given file
+// extension (matched case-insensitively), or an error if none is registered
+func FormatForExtension(ext string) (Format, error) {
+	format, ok := formats[strings.ToLower(ext)]
+	if !ok {
+		return nil, fmt.Errorf("pointcloud: no format registered for extension %q", ext)
+	}
+	return format, nil
+}
+
+// Read opens filename and reads it using the Format registered for its
+// extension
+func Read(filename string) ([]Point3D, error) {
+	format, err := FormatForExtension(filepath.Ext(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("pointcloud: could not open %q: %w", filename, err)
+	}
+	defer file.Close()
+
+	return format.Read(file)
+}
+
+// Write creates filename (or truncates it if it already exists) and writes
+// points to it using the Format registered for its extension
+func Write(filename string, points []Point3D) error {
+	format, err := FormatForExtension(filepath.Ext(filename))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("pointcloud: could not create %q: %w", filename, err)
+	}
+	defer file.Close()
+
+	return format.Write(file, points)
+}
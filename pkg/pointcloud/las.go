@@ -0,0 +1,137 @@
+package pointcloud
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+func init() {
+	RegisterFormat(".las", LASFormat{})
+}
+
+// lasHeaderSize is the size in bytes of a LAS 1.2 public header block
+const lasHeaderSize = 227
+
+// lasPointRecordSize is the size of a Point Data Record Format 0 entry
+const lasPointRecordSize = 20
+
+// LASFormat reads and writes a minimal subset of the LAS point cloud format:
+// the 1.2 public header and Point Data Record Format 0 (X, Y, Z only; no
+// intensity, classification, colour, or variable-length records)
+type LASFormat struct{}
+
+// Read parses the LAS public header to find the point count, scale, and
+// offset, then decodes that many Point Data Record Format 0 entries
+func (LASFormat) Read(r io.Reader) ([]Point3D, error) {
+	header := make([]byte, lasHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("pointcloud: truncated las header: %w", err)
+	}
+	if string(header[0:4]) != "LASF" {
+		return nil, fmt.Errorf("pointcloud: not a las file")
+	}
+
+	pointDataOffset := binary.LittleEndian.Uint32(header[96:100])
+	pointDataFormat := header[104]
+	pointRecordLength := binary.LittleEndian.Uint16(header[105:107])
+	pointCount := binary.LittleEndian.Uint32(header[107:111])
+	if pointDataFormat != 0 {
+		return nil, fmt.Errorf("pointcloud: unsupported las point data format %d", pointDataFormat)
+	}
+	if pointRecordLength < 12 {
+		return nil, fmt.Errorf("pointcloud: las point record length %d too short for X, Y, Z", pointRecordLength)
+	}
+	scaleX := float8(header[131:139])
+	scaleY := float8(header[139:147])
+	scaleZ := float8(header[147:155])
+	offsetX := float8(header[155:163])
+	offsetY := float8(header[163:171])
+	offsetZ := float8(header[171:179])
+
+	// Skip to the start of the point data, accounting for any variable-length
+	// records between the public header and the points
+	if skip := int64(pointDataOffset) - lasHeaderSize; skip > 0 {
+		if _, err := io.CopyN(io.Discard, r, skip); err != nil {
+			return nil, fmt.Errorf("pointcloud: could not skip to las point data: %w", err)
+		}
+	}
+
+	// Append rather than preallocate len(points) == pointCount: the header's
+	// point count is attacker-controlled and an implausible value would
+	// otherwise force a huge allocation before we've confirmed the body
+	// actually holds that many points
+	var points []Point3D
+	record := make([]byte, pointRecordLength)
+	for i := uint32(0); i < pointCount; i++ {
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, fmt.Errorf("pointcloud: las body ended early, got %d of %d points: %w", i, pointCount, err)
+		}
+		x := int32(binary.LittleEndian.Uint32(record[0:4]))
+		y := int32(binary.LittleEndian.Uint32(record[4:8]))
+		z := int32(binary.LittleEndian.Uint32(record[8:12]))
+		points = append(points, Point3D{
+			X: float64(x)*scaleX + offsetX,
+			Y: float64(y)*scaleY + offsetY,
+			Z: float64(z)*scaleZ + offsetZ,
+		})
+	}
+
+	return points, nil
+}
+
+// float8 reads a little-endian float64 from an 8-byte slice
+func float8(b []byte) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+// Write emits a minimal LAS 1.2 file (public header plus Point Data Record
+// Format 0 entries, no variable-length records) with a fixed scale of 0.001
+// and an offset taken from the first point
+func (LASFormat) Write(w io.Writer, points []Point3D) error {
+	header := make([]byte, lasHeaderSize)
+	copy(header[0:4], "LASF")
+	header[24] = 1                                               // version major
+	header[25] = 2                                               // version minor
+	binary.LittleEndian.PutUint16(header[94:96], lasHeaderSize)  // header size
+	binary.LittleEndian.PutUint32(header[96:100], lasHeaderSize) // offset to point data
+	header[104] = 0                                              // point data format 0
+	binary.LittleEndian.PutUint16(header[105:107], lasPointRecordSize)
+	binary.LittleEndian.PutUint32(header[107:111], uint32(len(points)))
+
+	const scale = 0.001
+	var offsetX, offsetY, offsetZ float64
+	if len(points) > 0 {
+		offsetX, offsetY, offsetZ = points[0].X, points[0].Y, points[0].Z
+	}
+	putFloat8(header[131:139], scale)
+	putFloat8(header[139:147], scale)
+	putFloat8(header[147:155], scale)
+	putFloat8(header[155:163], offsetX)
+	putFloat8(header[163:171], offsetY)
+	putFloat8(header[171:179], offsetZ)
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]byte, lasPointRecordSize)
+	for _, point := range points {
+		x := int32((point.X - offsetX) / scale)
+		y := int32((point.Y - offsetY) / scale)
+		z := int32((point.Z - offsetZ) / scale)
+		binary.LittleEndian.PutUint32(record[0:4], uint32(x))
+		binary.LittleEndian.PutUint32(record[4:8], uint32(y))
+		binary.LittleEndian.PutUint32(record[8:12], uint32(z))
+		if _, err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func putFloat8(b []byte, v float64) {
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+}
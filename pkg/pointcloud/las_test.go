@@ -0,0 +1,46 @@
+package pointcloud
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestLASFormatRoundTrip(t *testing.T) {
+	points := []Point3D{{X: 1.123, Y: -2.5, Z: 3}, {X: 0, Y: 0, Z: 0}}
+
+	var buf bytes.Buffer
+	if err := (LASFormat{}).Write(&buf, points); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := (LASFormat{}).Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != len(points) {
+		t.Fatalf("got %d points, want %d", len(got), len(points))
+	}
+	for i := range points {
+		if math.Abs(got[i].X-points[i].X) > 1e-3 ||
+			math.Abs(got[i].Y-points[i].Y) > 1e-3 ||
+			math.Abs(got[i].Z-points[i].Z) > 1e-3 {
+			t.Errorf("point %d = %v, want %v", i, got[i], points[i])
+		}
+	}
+}
+
+func TestLASFormatReadRejectsShortRecordLength(t *testing.T) {
+	header := make([]byte, lasHeaderSize)
+	copy(header[0:4], "LASF")
+	binary.LittleEndian.PutUint16(header[94:96], lasHeaderSize)
+	binary.LittleEndian.PutUint32(header[96:100], lasHeaderSize)
+	header[104] = 0                                   // point data format 0
+	binary.LittleEndian.PutUint16(header[105:107], 4) // too short to hold X, Y, Z
+	binary.LittleEndian.PutUint32(header[107:111], 1)
+
+	if _, err := (LASFormat{}).Read(bytes.NewReader(header)); err == nil {
+		t.Fatal("expected an error for a too-short point record length, got nil")
+	}
+}
@@ -0,0 +1,189 @@
+package pointcloud
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterFormat(".ply", PLYFormat{})
+}
+
+// PLYFormat reads and writes the Stanford PLY format, supporting both the
+// "ascii" and "binary_little_endian" vertex encodings. Only the x, y, z
+// vertex properties are read or written; other properties/elements present
+// in a file being read (e.g. face lists) are ignored
+type PLYFormat struct{}
+
+// Read parses a PLY header to determine the vertex count and encoding,
+// then reads that many vertices in the indicated format
+func (PLYFormat) Read(r io.Reader) ([]Point3D, error) {
+	br := bufio.NewReader(r)
+
+	count, binaryEncoding, err := readPLYHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	if binaryEncoding {
+		return readPLYVerticesBinary(br, count)
+	}
+	return readPLYVerticesASCII(br, count)
+}
+
+// readPLYHeader consumes lines up to and including "end_header", returning
+// the declared vertex count and whether the body is binary-encoded
+func readPLYHeader(br *bufio.Reader) (count int, binaryEncoding bool, err error) {
+	line, err := br.ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != "ply" {
+		return 0, false, fmt.Errorf("pointcloud: not a ply file")
+	}
+
+	for {
+		line, err = br.ReadString('\n')
+		if err != nil {
+			return 0, false, fmt.Errorf("pointcloud: truncated ply header: %w", err)
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "format":
+			if len(fields) < 2 {
+				return 0, false, fmt.Errorf("pointcloud: malformed ply format line")
+			}
+			switch fields[1] {
+			case "ascii":
+				binaryEncoding = false
+			case "binary_little_endian":
+				binaryEncoding = true
+			default:
+				return 0, false, fmt.Errorf("pointcloud: unsupported ply format %q", fields[1])
+			}
+		case "element":
+			if len(fields) >= 3 && fields[1] == "vertex" {
+				count, err = strconv.Atoi(fields[2])
+				if err != nil {
+					return 0, false, fmt.Errorf("pointcloud: malformed vertex count: %w", err)
+				}
+				if count < 0 {
+					return 0, false, fmt.Errorf("pointcloud: negative vertex count %d", count)
+				}
+			}
+		case "end_header":
+			return count, binaryEncoding, nil
+		}
+	}
+}
+
+func readPLYVerticesASCII(r io.Reader, count int) ([]Point3D, error) {
+	scanner := bufio.NewScanner(r)
+
+	// Append rather than preallocate len(points) == count: the header's
+	// vertex count is attacker-controlled and an implausible value would
+	// otherwise force a huge allocation before we've confirmed the body
+	// actually holds that many vertices
+	var points []Point3D
+	for i := 0; i < count; i++ {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("pointcloud: ply body ended early, got %d of %d vertices", i, count)
+		}
+		coords := strings.Fields(scanner.Text())
+		if len(coords) < 3 {
+			return nil, fmt.Errorf("pointcloud: malformed ply vertex line %q", scanner.Text())
+		}
+		x, errX := strconv.ParseFloat(coords[0], 64)
+		y, errY := strconv.ParseFloat(coords[1], 64)
+		z, errZ := strconv.ParseFloat(coords[2], 64)
+		if errX != nil || errY != nil || errZ != nil {
+			return nil, fmt.Errorf("pointcloud: malformed ply vertex line %q", scanner.Text())
+		}
+		points = append(points, Point3D{X: x, Y: y, Z: z})
+	}
+
+	return points, nil
+}
+
+func readPLYVerticesBinary(r io.Reader, count int) ([]Point3D, error) {
+	// Append rather than preallocate len(points) == count: see the comment
+	// in readPLYVerticesASCII
+	var points []Point3D
+	var buf [24]byte // 3 * float64 little-endian
+
+	for i := 0; i < count; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, fmt.Errorf("pointcloud: ply body ended early, got %d of %d vertices: %w", i, count, err)
+		}
+		points = append(points, Point3D{
+			X: math.Float64frombits(binary.LittleEndian.Uint64(buf[0:8])),
+			Y: math.Float64frombits(binary.LittleEndian.Uint64(buf[8:16])),
+			Z: math.Float64frombits(binary.LittleEndian.Uint64(buf[16:24])),
+		})
+	}
+
+	return points, nil
+}
+
+// Write writes points as an ASCII PLY file with a single vertex element
+func (PLYFormat) Write(w io.Writer, points []Point3D) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "ply\n")
+	fmt.Fprintf(bw, "format ascii 1.0\n")
+	fmt.Fprintf(bw, "element vertex %d\n", len(points))
+	fmt.Fprintf(bw, "property double x\n")
+	fmt.Fprintf(bw, "property double y\n")
+	fmt.Fprintf(bw, "property double z\n")
+	fmt.Fprintf(bw, "end_header\n")
+
+	for _, point := range points {
+		if _, err := fmt.Fprintf(bw, "%v %v %v\n", point.X, point.Y, point.Z); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WritePolygonPLY writes polygon (an ordered, coplanar ring of vertices, e.g.
+// from planefit.ExtractBoundedPolygon) to an ASCII PLY file as a single face
+func WritePolygonPLY(w io.Writer, polygon []Point3D) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "ply\n")
+	fmt.Fprintf(bw, "format ascii 1.0\n")
+	fmt.Fprintf(bw, "element vertex %d\n", len(polygon))
+	fmt.Fprintf(bw, "property double x\n")
+	fmt.Fprintf(bw, "property double y\n")
+	fmt.Fprintf(bw, "property double z\n")
+	fmt.Fprintf(bw, "element face 1\n")
+	fmt.Fprintf(bw, "property list uchar int vertex_indices\n")
+	fmt.Fprintf(bw, "end_header\n")
+
+	for _, point := range polygon {
+		if _, err := fmt.Fprintf(bw, "%v %v %v\n", point.X, point.Y, point.Z); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(bw, "%d", len(polygon)); err != nil {
+		return err
+	}
+	for i := range polygon {
+		if _, err := fmt.Fprintf(bw, " %d", i); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(bw, "\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,43 @@
+package pointcloud
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestPLYFormatASCIIRoundTrip(t *testing.T) {
+	points := []Point3D{{X: 1, Y: 2, Z: 3}, {X: -1.5, Y: 0, Z: 9.25}}
+
+	var buf bytes.Buffer
+	if err := (PLYFormat{}).Write(&buf, points); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := (PLYFormat{}).Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !reflect.DeepEqual(got, points) {
+		t.Errorf("points = %v, want %v", got, points)
+	}
+}
+
+func TestPLYFormatReadTruncatedBinaryBody(t *testing.T) {
+	header := "ply\n" +
+		"format binary_little_endian 1.0\n" +
+		"element vertex 2\n" +
+		"property double x\n" +
+		"property double y\n" +
+		"property double z\n" +
+		"end_header\n"
+
+	var body bytes.Buffer
+	body.WriteString(header)
+	body.Write(make([]byte, 24)) // one complete vertex record
+	body.Write(make([]byte, 10)) // second record cut short
+
+	if _, err := (PLYFormat{}).Read(&body); err == nil {
+		t.Fatal("expected an error for a truncated binary body, got nil")
+	}
+}
@@ -0,0 +1,10 @@
+// Package pointcloud provides a Point3D representation and pluggable
+// Format readers/writers for common point cloud file formats.
+package pointcloud
+
+// A single point in 3D space
+type Point3D struct {
+	X float64
+	Y float64
+	Z float64
+}
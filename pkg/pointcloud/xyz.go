@@ -0,0 +1,87 @@
+package pointcloud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Scanner buffer size for XYZ files with very long lines; the default
+// bufio.MaxScanTokenSize (64KiB) is occasionally too small for clouds that
+// pack many points per line
+const xyzScannerBufferSize = 1024 * 1024
+
+func init() {
+	RegisterFormat(".xyz", XYZFormat{})
+}
+
+// XYZFormat reads and writes the plain-text "X Y Z" point cloud format
+type XYZFormat struct{}
+
+// Read streams an XYZ file, tolerating a header line, blank lines, and
+// comment lines (starting with '#'). Rows that don't parse as three
+// whitespace-separated floats are skipped rather than aborting the read;
+// if any rows were skipped, Read returns the parsed points alongside an
+// error reporting how many
+func (XYZFormat) Read(r io.Reader) ([]Point3D, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, xyzScannerBufferSize), xyzScannerBufferSize)
+
+	var points []Point3D
+	skipped := 0
+	sawHeader := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !sawHeader {
+			// The first non-comment, non-blank line is the "X Y Z" header
+			sawHeader = true
+			continue
+		}
+
+		coords := strings.Fields(line)
+		if len(coords) < 3 {
+			skipped++
+			continue
+		}
+		x, errX := strconv.ParseFloat(coords[0], 64)
+		y, errY := strconv.ParseFloat(coords[1], 64)
+		z, errZ := strconv.ParseFloat(coords[2], 64)
+		if errX != nil || errY != nil || errZ != nil {
+			skipped++
+			continue
+		}
+
+		points = append(points, Point3D{X: x, Y: y, Z: z})
+	}
+	if err := scanner.Err(); err != nil {
+		return points, fmt.Errorf("pointcloud: error scanning xyz: %w", err)
+	}
+
+	if skipped > 0 {
+		return points, fmt.Errorf("pointcloud: skipped %d malformed row(s)", skipped)
+	}
+	return points, nil
+}
+
+// Write writes points as an XYZ file with an "X Y Z" header
+func (XYZFormat) Write(w io.Writer, points []Point3D) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.WriteString("X Y Z\n"); err != nil {
+		return err
+	}
+	for _, point := range points {
+		// Using %f causes precision loss
+		if _, err := fmt.Fprintf(bw, "%v %v %v\n", point.X, point.Y, point.Z); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
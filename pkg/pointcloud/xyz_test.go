@@ -0,0 +1,38 @@
+package pointcloud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestXYZFormatReadToleratesCommentsAndBlankLines(t *testing.T) {
+	input := "X Y Z\n# a comment\n\n1 2 3\n\n4 5 6\n"
+
+	points, err := XYZFormat{}.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	want := []Point3D{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("points = %v, want %v", points, want)
+	}
+}
+
+func TestXYZFormatReadSkipsMalformedRows(t *testing.T) {
+	input := "X Y Z\n1 2 3\nnot a point\n4 5 6\n"
+
+	points, err := XYZFormat{}.Read(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("expected an error reporting the skipped row, got nil")
+	}
+	if !strings.Contains(err.Error(), "skipped 1") {
+		t.Errorf("error %q does not mention the skipped row count", err)
+	}
+
+	want := []Point3D{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("points = %v, want %v", points, want)
+	}
+}
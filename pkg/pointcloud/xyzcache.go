@@ -0,0 +1,149 @@
+package pointcloud
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// xyzCacheMagic and xyzCacheVersion identify the binary sidecar cache format
+const (
+	xyzCacheMagic   = "P3DC"
+	xyzCacheVersion = 1
+)
+
+// xyzCacheHeaderSize is magic (4 bytes) + version (uint32) + point count (uint64)
+const xyzCacheHeaderSize = 4 + 4 + 8
+
+// xyzRecordSize is one Point3D encoded as 3 little-endian float64s
+const xyzRecordSize = 24
+
+// ReadXYZCached reads the XYZ file at path, using a gzipped binary sidecar
+// cache (path + ".cache.bin.gz") to skip re-parsing large ASCII clouds on
+// repeated runs. If the cache exists and is newer than path, it is read
+// directly; otherwise path is parsed with ReadXYZ and the cache is written
+// (atomically, via a temp file + rename) for next time
+func ReadXYZCached(path string) []Point3D {
+	cachePath := path + ".cache.bin.gz"
+
+	if points, ok := readXYZCache(path, cachePath); ok {
+		return points
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		panic(fmt.Errorf("pointcloud: could not open %q: %w", path, err))
+	}
+	points, err := XYZFormat{}.Read(file)
+	file.Close()
+	if err != nil && points == nil {
+		panic(fmt.Errorf("pointcloud: could not read %q: %w", path, err))
+	}
+
+	if err := writeXYZCache(cachePath, points); err != nil {
+		fmt.Printf("pointcloud: could not write cache %q: %v\n", cachePath, err)
+	}
+
+	return points
+}
+
+// readXYZCache returns the points stored in cachePath, provided it exists,
+// is newer than sourcePath, and parses cleanly; ok is false otherwise (the
+// caller is expected to fall back to a plain read of sourcePath)
+func readXYZCache(sourcePath, cachePath string) (points []Point3D, ok bool) {
+	cacheInfo, err := os.Stat(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil || cacheInfo.ModTime().Before(sourceInfo.ModTime()) {
+		return nil, false
+	}
+
+	file, err := os.Open(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, false
+	}
+	defer gz.Close()
+
+	header := make([]byte, xyzCacheHeaderSize)
+	if _, err := io.ReadFull(gz, header); err != nil {
+		return nil, false
+	}
+	if string(header[0:4]) != xyzCacheMagic || binary.LittleEndian.Uint32(header[4:8]) != xyzCacheVersion {
+		return nil, false
+	}
+	count := binary.LittleEndian.Uint64(header[8:16])
+
+	// Append rather than preallocate len(points) == count: a corrupt header
+	// (e.g. a truncated write) could otherwise claim an implausible count and
+	// force a huge allocation before we've confirmed the data backing it exists
+	record := make([]byte, xyzRecordSize)
+	for i := uint64(0); i < count; i++ {
+		if _, err := io.ReadFull(gz, record); err != nil {
+			return nil, false
+		}
+		points = append(points, Point3D{
+			X: math.Float64frombits(binary.LittleEndian.Uint64(record[0:8])),
+			Y: math.Float64frombits(binary.LittleEndian.Uint64(record[8:16])),
+			Z: math.Float64frombits(binary.LittleEndian.Uint64(record[16:24])),
+		})
+	}
+
+	return points, true
+}
+
+// writeXYZCache gzip-encodes points as a header (magic, version, count)
+// followed by little-endian float64 triples, writing to a temp file and
+// renaming over cachePath so a reader never observes a partial cache
+func writeXYZCache(cachePath string, points []Point3D) error {
+	tmpPath := cachePath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	gz := gzip.NewWriter(file)
+
+	header := make([]byte, xyzCacheHeaderSize)
+	copy(header[0:4], xyzCacheMagic)
+	binary.LittleEndian.PutUint32(header[4:8], xyzCacheVersion)
+	binary.LittleEndian.PutUint64(header[8:16], uint64(len(points)))
+	if _, err := gz.Write(header); err != nil {
+		gz.Close()
+		file.Close()
+		return err
+	}
+
+	record := make([]byte, xyzRecordSize)
+	for _, p := range points {
+		binary.LittleEndian.PutUint64(record[0:8], math.Float64bits(p.X))
+		binary.LittleEndian.PutUint64(record[8:16], math.Float64bits(p.Y))
+		binary.LittleEndian.PutUint64(record[16:24], math.Float64bits(p.Z))
+		if _, err := gz.Write(record); err != nil {
+			gz.Close()
+			file.Close()
+			return err
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, cachePath)
+}
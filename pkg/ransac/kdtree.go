@@ -0,0 +1,108 @@
+package ransac
+
+import (
+	"sort"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+// kdTree is a static, balanced k-d tree over a point cloud's indices, built
+// once and used to answer repeated k-nearest-neighbour queries (used by
+// LocalitySampler to bias triplet generation towards nearby points)
+type kdTree struct {
+	points []pointcloud.Point3D
+	root   *kdNode
+}
+
+type kdNode struct {
+	index       int
+	axis        int
+	left, right *kdNode
+}
+
+// newKDTree builds a balanced k-d tree by recursively splitting on the
+// median of the widest-spread axis (cycling X, Y, Z)
+func newKDTree(points []pointcloud.Point3D) *kdTree {
+	indices := make([]int, len(points))
+	for i := range indices {
+		indices[i] = i
+	}
+	return &kdTree{points: points, root: buildKDNode(points, indices, 0)}
+}
+
+func buildKDNode(points []pointcloud.Point3D, indices []int, axis int) *kdNode {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	sort.Slice(indices, func(i, j int) bool {
+		return axisValue(points[indices[i]], axis) < axisValue(points[indices[j]], axis)
+	})
+	mid := len(indices) / 2
+
+	return &kdNode{
+		index: indices[mid],
+		axis:  axis,
+		left:  buildKDNode(points, indices[:mid], (axis+1)%3),
+		right: buildKDNode(points, indices[mid+1:], (axis+1)%3),
+	}
+}
+
+func axisValue(p pointcloud.Point3D, axis int) float64 {
+	switch axis {
+	case 0:
+		return p.X
+	case 1:
+		return p.Y
+	default:
+		return p.Z
+	}
+}
+
+func distanceSquared(a, b pointcloud.Point3D) float64 {
+	dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+	return dx*dx + dy*dy + dz*dz
+}
+
+// kNearestNeighbours returns the indices of the k points nearest to target,
+// excluding the point at excludeIndex, sorted nearest-first
+func (t *kdTree) kNearestNeighbours(target pointcloud.Point3D, excludeIndex, k int) []int {
+	best := make([]int, 0, k)
+	bestDist := make([]float64, 0, k)
+
+	var visit func(n *kdNode)
+	visit = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		if n.index != excludeIndex {
+			d := distanceSquared(target, t.points[n.index])
+			if len(best) < k || d < bestDist[len(bestDist)-1] {
+				pos := sort.SearchFloat64s(bestDist, d)
+				best = append(best, 0)
+				bestDist = append(bestDist, 0)
+				copy(best[pos+1:], best[pos:])
+				copy(bestDist[pos+1:], bestDist[pos:])
+				best[pos] = n.index
+				bestDist[pos] = d
+				if len(best) > k {
+					best = best[:k]
+					bestDist = bestDist[:k]
+				}
+			}
+		}
+
+		diff := axisValue(target, n.axis) - axisValue(t.points[n.index], n.axis)
+		near, far := n.left, n.right
+		if diff > 0 {
+			near, far = n.right, n.left
+		}
+		visit(near)
+		if len(best) < k || diff*diff < bestDist[len(bestDist)-1] {
+			visit(far)
+		}
+	}
+	visit(t.root)
+
+	return best
+}
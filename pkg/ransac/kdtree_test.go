@@ -0,0 +1,54 @@
+package ransac
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+// bruteForceKNearestNeighbours is a simple O(n log n) reference
+// implementation to check kdTree.kNearestNeighbours against
+func bruteForceKNearestNeighbours(points []pointcloud.Point3D, target pointcloud.Point3D, excludeIndex, k int) []int {
+	indices := make([]int, 0, len(points))
+	for i := range points {
+		if i != excludeIndex {
+			indices = append(indices, i)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return distanceSquared(target, points[indices[i]]) < distanceSquared(target, points[indices[j]])
+	})
+	if len(indices) > k {
+		indices = indices[:k]
+	}
+	return indices
+}
+
+func TestKDTreeKNearestNeighboursMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := make([]pointcloud.Point3D, 200)
+	for i := range points {
+		points[i] = pointcloud.Point3D{X: rng.Float64() * 100, Y: rng.Float64() * 100, Z: rng.Float64() * 100}
+	}
+
+	tree := newKDTree(points)
+	const k = 5
+
+	for excludeIndex := 0; excludeIndex < len(points); excludeIndex += 10 {
+		got := tree.kNearestNeighbours(points[excludeIndex], excludeIndex, k)
+		want := bruteForceKNearestNeighbours(points, points[excludeIndex], excludeIndex, k)
+
+		if len(got) != len(want) {
+			t.Fatalf("index %d: got %d neighbours, want %d", excludeIndex, len(got), len(want))
+		}
+		for i := range want {
+			gotDist := distanceSquared(points[excludeIndex], points[got[i]])
+			wantDist := distanceSquared(points[excludeIndex], points[want[i]])
+			if gotDist != wantDist {
+				t.Errorf("index %d, neighbour %d: distance %v, want %v", excludeIndex, i, gotDist, wantDist)
+			}
+		}
+	}
+}
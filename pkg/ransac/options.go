@@ -0,0 +1,41 @@
+package ransac
+
+import (
+	"math/rand"
+	"runtime"
+)
+
+// Options configures a call to RunWithOptions
+type Options struct {
+	Workers    int     // number of scoring workers; defaults to runtime.NumCPU() when <= 0
+	Iterations int     // number of candidate triplets to try
+	Eps        float64 // inlier distance threshold
+	Seed       int64   // seeds triplet sampling; 0 leaves the global math/rand source untouched
+	Scorer     Scorer  // ranks candidate planes; defaults to RANSACScorer
+	Sampler    Sampler // draws candidate triplets; defaults to UniformSampler
+}
+
+// workers returns o.Workers, defaulting to runtime.NumCPU() when unset
+func (o Options) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.NumCPU()
+}
+
+// scorer returns o.Scorer, defaulting to RANSACScorer when unset
+func (o Options) scorer() Scorer {
+	if o.Scorer != nil {
+		return o.Scorer
+	}
+	return RANSACScorer{}
+}
+
+// sampler returns o.Sampler, defaulting to UniformSampler (seeded with rng)
+// when unset
+func (o Options) sampler(rng *rand.Rand) Sampler {
+	if o.Sampler != nil {
+		return o.Sampler
+	}
+	return UniformSampler{Rng: rng}
+}
@@ -0,0 +1,197 @@
+package ransac
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+//**PIPELINE**//
+
+//Note: triplet generation runs on its own goroutine, feeding a bounded pool
+//of scoring workers (see runWorkerPool below) rather than spawning a
+//goroutine per candidate plane.
+
+// nextIndex draws a random index in [0, n). When rng is nil, it falls back
+// to the global math/rand source (left unseeded, as a control variable)
+func nextIndex(rng *rand.Rand, n int) int {
+	if rng != nil {
+		return rng.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// Randomly selects a point from the provided slice of Point3D (point cloud) -> transmits instances of Point3D through output channel
+func RandomPointGenerator(points []pointcloud.Point3D, stop <-chan bool, rng *rand.Rand) <-chan pointcloud.Point3D {
+
+	/*
+		Channels:
+		- This function returns a stream (receive-only channel) through which random points are transmitted;
+		  by writing <-chan _ as the return type, it means the CALLER of this function can only receive
+		  values from the channel; however, the function itself can send values to this channel!
+		- Unlike the remaining stages of the pipeline, this function is given a receive-only control channel, stop,
+		  which is populated by TakeN(). This is necessary since the termination of this stage depends on a later
+		  stage in the pipeline, rather than a previous one.
+		- When stop is sent a value, the output channel of this function, randomPointStream, is closed, and initiates
+		  the termination cascade of the pipeline (i.e., TripletGenerator terminates when randomPointStream is closed, and so on...)
+
+		The select statement below will block until 'stop' has been sent a value, causing the
+		goroutine to terminate, OR until randomPointStream's value has been received, at which point we
+		can send a new random point.
+	*/
+
+	randomPointStream := make(chan pointcloud.Point3D)
+	go func() {
+		defer close(randomPointStream) //crucial since closing this channel is how we terminate the entire pipeline!
+		n := len(points)
+		randomIndex := nextIndex(rng, n)
+		for {
+			select {
+			case <-stop:
+				return
+			case randomPointStream <- points[randomIndex]:
+				randomIndex = nextIndex(rng, n)
+			}
+		}
+	}()
+	return randomPointStream
+}
+
+// (Helper) returns true if target point is contained within array of points, false otherwise
+func ContainsPoint(target pointcloud.Point3D, points [3]pointcloud.Point3D) bool {
+	for _, p := range points {
+		if p == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Reads Point3D instances from input channel and accumulates 3 points -> transmits arrays of Point3D (composed of three points) through output channel
+func TripletGenerator(randomPointStream <-chan pointcloud.Point3D) <-chan [3]pointcloud.Point3D {
+	tripletStream := make(chan [3]pointcloud.Point3D)
+	go func() {
+		defer close(tripletStream)
+		for {
+			//1) Gathers three points from input stream into array
+			i := 0
+			triplet := [3]pointcloud.Point3D{}
+			for i < 3 {
+				randomPoint, open := <-randomPointStream //blocks until randomPointStream has a new value to send, OR is closed
+				if open == false {                       //if randomPointStream is closed, we terminate this goroutine
+					return
+				}
+				if !ContainsPoint(randomPoint, triplet) {
+					triplet[i] = randomPoint
+					i++
+				}
+			}
+			//2) Sends array to output stream
+			tripletStream <- triplet //blocks until tripletStream can be sent another value (i.e., it is empty)
+		}
+	}()
+	return tripletStream
+}
+
+// Reads array of Point3D from its input channel -> retransmits array of Point3D through its output channel. Automatically stops the pipeline after having recieved n slices.
+func TakeN(n int, tripletStream <-chan [3]pointcloud.Point3D, stop chan<- bool) <-chan [3]pointcloud.Point3D {
+	//Here, stop is the same channel that is passed to RandomPointGenerator()
+	nTripletStream := make(chan [3]pointcloud.Point3D)
+	go func() {
+		defer close(nTripletStream)
+		numTriplets := 0
+		for numTriplets < n {
+			triplet := <-tripletStream //blocks until tripletStream is sent a new value
+			nTripletStream <- triplet  //blocks until nTripletStream can recieve a new value (i.e., until it is empty)
+			numTriplets++
+		}
+		stop <- true //initiates termination cascade of generators (randomGenerator terminates and then forces tripletStream to terminate, etc.)
+	}()
+	return nTripletStream
+}
+
+// Reads array of Point3D from its input channel and computes the plane defined by those points -> transmits Plane3D instances through output channel
+func PlaneEstimator(nTripletStream <-chan [3]pointcloud.Point3D) <-chan Plane3D {
+	//Only passed a single channel so there is no need to use select; instead, we terminate contained goroutine when nTripletStream is closed
+	planeStream := make(chan Plane3D)
+	go func() {
+		defer close(planeStream)
+		//The below range blocks until nTripletStream has a new value to send, and breaks when nTripletStream is closed!
+		//This style is equivelant to a for {} containing a manual check if the input stream is closed as a means of breaking!
+		for triplet := range nTripletStream {
+			plane := GetPlane(triplet)
+			planeStream <- plane //blocks until planeStream can be sent a new value (i.e., when it is empty)
+		}
+	}()
+	return planeStream
+}
+
+// scoredPlane pairs a candidate plane with the cost a Scorer assigned it
+type scoredPlane struct {
+	Plane3D
+	cost float64
+}
+
+// runWorkerPool drains planeStream through a bounded pool of workers, each
+// scoring candidate planes against points via scorer, and reduces their
+// results to the single lowest-cost plane. Its SupportSize is filled in
+// afterwards with the plain eps-based inlier count, independent of which
+// Scorer won
+func runWorkerPool(points []pointcloud.Point3D, eps float64, workers int, scorer Scorer, planeStream <-chan Plane3D) Plane3DwSupport {
+	results := make(chan scoredPlane)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for plane := range planeStream { //every worker pulls from the same stream, so planes are divided across the pool rather than one goroutine per plane
+				results <- scoredPlane{Plane3D: plane, cost: scorer.Score(plane, points, eps)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	best := scoredPlane{cost: math.Inf(1)}
+	for candidate := range results { //range blocks until results has a new value to transmit and breaks when closed (i.e., every worker has finished)
+		if candidate.cost < best.cost {
+			best = candidate
+		}
+	}
+
+	return Plane3DwSupport{Plane3D: best.Plane3D, SupportSize: getSupportFast(best.Plane3D, points, eps)}
+}
+
+// Run executes the pipeline given a point cloud, number of iterations, and epsilon value; assigns the most well-supported plane to the given variable in memory
+func Run(points []pointcloud.Point3D, numIterations int, eps float64, dominant *Plane3DwSupport) {
+	*dominant = RunWithOptions(points, Options{Iterations: numIterations, Eps: eps})
+}
+
+// RunWithOptions runs the pipeline per opts and returns the best plane
+// found, as ranked by opts.Scorer (RANSACScorer by default) and sampled via
+// opts.Sampler (UniformSampler by default)
+func RunWithOptions(points []pointcloud.Point3D, opts Options) Plane3DwSupport {
+	var rng *rand.Rand
+	if opts.Seed != 0 {
+		rng = rand.New(rand.NewSource(opts.Seed))
+	}
+
+	stop := make(chan bool)
+	tripletStream := opts.sampler(rng).Stream(points, stop)
+	nTripletStream := TakeN(opts.Iterations, tripletStream, stop)
+	planeStream := PlaneEstimator(nTripletStream)
+
+	scorer := opts.scorer()
+	if p, ok := scorer.(preparer); ok {
+		// Precompute whatever per-run state scorer needs (e.g. MLESACScorer's
+		// bounding-box volume) once, rather than on every call to Score
+		scorer = p.prepare(points)
+	}
+
+	return runWorkerPool(points, opts.Eps, opts.workers(), scorer, planeStream)
+}
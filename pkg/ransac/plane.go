@@ -0,0 +1,148 @@
+// Package ransac implements plane fitting over a point cloud using the
+// RANSAC algorithm.
+package ransac
+
+import (
+	"math"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+type Plane3D struct {
+	A float64
+	B float64
+	C float64
+	D float64
+}
+type Plane3DwSupport struct {
+	Plane3D
+	SupportSize int
+}
+
+// Helper function used by GetPlane()
+func crossProduct(A, B []float64) []float64 {
+	return []float64{
+		A[1]*B[2] - A[2]*B[1],
+		A[2]*B[0] - A[0]*B[2],
+		A[0]*B[1] - A[1]*B[0],
+	}
+}
+
+// Computes the plane defined by a set of 3 points
+func GetPlane(points [3]pointcloud.Point3D) Plane3D {
+
+	//Assuming desired from of plane equaiton is: Ax + By + Cz = D
+
+	p1, p2, p3 := points[0], points[1], points[2]
+	v1 := []float64{p2.X - p1.X, p2.Y - p1.Y, p2.Z - p1.Z} //vector 1
+	v2 := []float64{p3.X - p1.X, p3.Y - p1.Y, p3.Z - p1.Z} //vector 2
+	N := crossProduct(v1, v2)                              //normal vector
+
+	result := Plane3D{
+		A: N[0],
+		B: N[1],
+		C: N[2],
+	}
+	result.D = (result.A * p1.X) + (result.B * p1.Y) + (result.C * p1.Z)
+	return result
+}
+
+// (Method) Computes distance between a plane and a given point
+func (pl *Plane3D) GetDistance(pt pointcloud.Point3D) float64 {
+	return math.Abs((pl.A*pt.X)+(pl.B*pt.Y)+(pl.C*pt.Z)-pl.D) / math.Sqrt(math.Pow(pl.A, 2)+math.Pow(pl.B, 2)+math.Pow(pl.C, 2))
+}
+
+// Computes the number of required RANSAC iterations
+func GetNumberOfIterations(confidence, percentageOfPointsOnPlane float64) int {
+	//Handling case where arguments are passed as percentages
+	if confidence > 1 {
+		confidence = confidence / 100
+	}
+	if percentageOfPointsOnPlane > 1 {
+		percentageOfPointsOnPlane = percentageOfPointsOnPlane / 100
+	}
+
+	//The value we return is the number of random triplets we must pick from the cloud to find the dom. plane ( = number of req. iterations )
+	return int(math.Log10(1-confidence) / math.Log10(1-math.Pow(percentageOfPointsOnPlane, 3)))
+}
+
+// Computes the support of a plane in a set of points
+func GetSupport(plane Plane3D, points []pointcloud.Point3D, eps float64) Plane3DwSupport {
+
+	support := 0
+
+	//"Count the number of points that are at a distance LESS than eps (ε)..."
+
+	for _, pt := range points {
+		if plane.GetDistance(pt) < eps {
+			support++
+		}
+	}
+
+	return Plane3DwSupport{
+		Plane3D:     plane,
+		SupportSize: support,
+	}
+}
+
+// supportChunkSize is the block size used by getSupportFast to sum
+// per-chunk local accumulators rather than incrementing one shared counter
+// per point
+const supportChunkSize = 4096
+
+// getSupportFast is a tuned variant of GetSupport used by the worker pool's
+// hot loop: it inlines the distance-squared comparison ((Ax+By+Cz-D)² <
+// eps²·(A²+B²+C²)) to avoid the sqrt and method-call overhead of
+// Plane3D.GetDistance, and walks points in chunks, accumulating each
+// chunk's count locally before folding it into the running total
+func getSupportFast(plane Plane3D, points []pointcloud.Point3D, eps float64) int {
+	A, B, C, D := plane.A, plane.B, plane.C, plane.D
+	epsSq := eps * eps * (A*A + B*B + C*C)
+
+	support := 0
+	for start := 0; start < len(points); start += supportChunkSize {
+		end := start + supportChunkSize
+		if end > len(points) {
+			end = len(points)
+		}
+
+		chunkSupport := 0
+		for _, pt := range points[start:end] {
+			d := A*pt.X + B*pt.Y + C*pt.Z - D
+			if d*d < epsSq {
+				chunkSupport++
+			}
+		}
+		support += chunkSupport
+	}
+
+	return support
+}
+
+// Extracts the points that supports the given plane and returns them as a slice of points
+func GetSupportingPoints(plane Plane3D, points []pointcloud.Point3D, eps float64) []pointcloud.Point3D {
+
+	inliers := []pointcloud.Point3D{}
+
+	for _, pt := range points {
+		if plane.GetDistance(pt) < eps {
+			inliers = append(inliers, pt)
+		}
+	}
+
+	return inliers
+}
+
+// Creates a new slice of points in which all points belonging to the plane have been removed
+func RemovePlane(plane Plane3D, points []pointcloud.Point3D, eps float64) []pointcloud.Point3D {
+
+	remainder := []pointcloud.Point3D{}
+
+	for _, pt := range points {
+		if plane.GetDistance(pt) >= eps {
+			remainder = append(remainder, pt)
+		}
+	}
+
+	return remainder
+}
@@ -0,0 +1,133 @@
+package ransac
+
+import (
+	"math"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+// maxRefitRounds bounds RefitIterative's refit/re-inlier loop
+const maxRefitRounds = 5
+
+// jacobiMaxSweeps and jacobiTolerance bound jacobiEigen3x3's rotation loop
+const (
+	jacobiMaxSweeps = 50
+	jacobiTolerance = 1e-12
+)
+
+// RefitPlane fits a plane to inliers via total-least-squares: it computes
+// the centroid c and the 3x3 covariance matrix M = sum((p-c)(p-c)^T), then
+// returns the plane whose normal is M's eigenvector with the smallest
+// eigenvalue (the direction inliers vary least along) and whose D is
+// N . c. This is a better fit than GetPlane's exact-triplet plane, which
+// is only as good as the one triplet RANSAC happened to sample
+func RefitPlane(inliers []pointcloud.Point3D) Plane3D {
+	var centroid pointcloud.Point3D
+	for _, p := range inliers {
+		centroid.X += p.X
+		centroid.Y += p.Y
+		centroid.Z += p.Z
+	}
+	n := float64(len(inliers))
+	centroid.X /= n
+	centroid.Y /= n
+	centroid.Z /= n
+
+	var cov [3][3]float64
+	for _, p := range inliers {
+		d := [3]float64{p.X - centroid.X, p.Y - centroid.Y, p.Z - centroid.Z}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				cov[i][j] += d[i] * d[j]
+			}
+		}
+	}
+
+	eigenvalues, eigenvectors := jacobiEigen3x3(cov)
+
+	smallest := 0
+	for i := 1; i < 3; i++ {
+		if eigenvalues[i] < eigenvalues[smallest] {
+			smallest = i
+		}
+	}
+	normal := pointcloud.Point3D{X: eigenvectors[0][smallest], Y: eigenvectors[1][smallest], Z: eigenvectors[2][smallest]}
+
+	return Plane3D{
+		A: normal.X,
+		B: normal.Y,
+		C: normal.Z,
+		D: normal.X*centroid.X + normal.Y*centroid.Y + normal.Z*centroid.Z,
+	}
+}
+
+// RefitIterative repeatedly refits the plane via RefitPlane, each round
+// re-deriving the inlier set from cloud with the refined plane, until the
+// inlier set stops changing size or maxRefitRounds rounds have elapsed
+func RefitIterative(cloud []pointcloud.Point3D, initial Plane3D, eps float64) Plane3D {
+	plane := initial
+	inliers := GetSupportingPoints(plane, cloud, eps)
+
+	for round := 0; round < maxRefitRounds && len(inliers) >= 3; round++ {
+		plane = RefitPlane(inliers)
+		refitted := GetSupportingPoints(plane, cloud, eps)
+		if len(refitted) == len(inliers) {
+			break
+		}
+		inliers = refitted
+	}
+
+	return plane
+}
+
+// jacobiEigen3x3 finds the eigenvalues and eigenvectors of a symmetric 3x3
+// matrix using the classic Jacobi eigenvalue algorithm: repeatedly rotate
+// away the largest off-diagonal entry until all off-diagonal entries fall
+// below jacobiTolerance or jacobiMaxSweeps sweeps have elapsed. Eigenvectors
+// are returned as the columns of the returned matrix
+func jacobiEigen3x3(m [3][3]float64) (eigenvalues [3]float64, eigenvectors [3][3]float64) {
+	a := m
+	v := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		// Find the largest off-diagonal entry
+		p, q := 0, 1
+		maxOffDiag := math.Abs(a[0][1])
+		if abs := math.Abs(a[0][2]); abs > maxOffDiag {
+			p, q, maxOffDiag = 0, 2, abs
+		}
+		if abs := math.Abs(a[1][2]); abs > maxOffDiag {
+			p, q, maxOffDiag = 1, 2, abs
+		}
+		if maxOffDiag < jacobiTolerance {
+			break
+		}
+
+		// Rotation angle that zeroes a[p][q]
+		theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+		t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+		if theta < 0 {
+			t = -t
+		}
+		c := 1 / math.Sqrt(t*t+1)
+		s := t * c
+
+		app, aqq, apq := a[p][p], a[q][q], a[p][q]
+		a[p][p] = app - t*apq
+		a[q][q] = aqq + t*apq
+		a[p][q], a[q][p] = 0, 0
+
+		r := 3 - p - q // the remaining index
+		arp, arq := a[r][p], a[r][q]
+		a[r][p], a[p][r] = c*arp-s*arq, c*arp-s*arq
+		a[r][q], a[q][r] = s*arp+c*arq, s*arp+c*arq
+
+		for i := 0; i < 3; i++ {
+			vip, viq := v[i][p], v[i][q]
+			v[i][p] = c*vip - s*viq
+			v[i][q] = s*vip + c*viq
+		}
+	}
+
+	return [3]float64{a[0][0], a[1][1], a[2][2]}, v
+}
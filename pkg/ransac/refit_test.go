@@ -0,0 +1,63 @@
+package ransac
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// checkEigenvectors verifies that each returned eigenvector v (paired with
+// eigenvalue values[i] in the same column) satisfies m*v = values[i]*v and
+// has unit norm
+func checkEigenvectors(t *testing.T, m [3][3]float64, values [3]float64, vectors [3][3]float64) {
+	t.Helper()
+	for col := 0; col < 3; col++ {
+		v := [3]float64{vectors[0][col], vectors[1][col], vectors[2][col]}
+
+		norm := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+		if math.Abs(norm-1) > 1e-9 {
+			t.Errorf("eigenvector %d has norm %v, want 1", col, norm)
+		}
+
+		for row := 0; row < 3; row++ {
+			mv := m[row][0]*v[0] + m[row][1]*v[1] + m[row][2]*v[2]
+			if math.Abs(mv-values[col]*v[row]) > 1e-9 {
+				t.Errorf("m*v != lambda*v for eigenvector %d at row %d: got %v, want %v", col, row, mv, values[col]*v[row])
+			}
+		}
+	}
+}
+
+func TestJacobiEigen3x3DiagonalMatrix(t *testing.T) {
+	m := [3][3]float64{{2, 0, 0}, {0, 3, 0}, {0, 0, 5}}
+
+	values, vectors := jacobiEigen3x3(m)
+
+	got := append([]float64{}, values[:]...)
+	sort.Float64s(got)
+	want := []float64{2, 3, 5}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("eigenvalues = %v, want %v", got, want)
+		}
+	}
+	checkEigenvectors(t, m, values, vectors)
+}
+
+func TestJacobiEigen3x3KnownMatrix(t *testing.T) {
+	// The top-left 2x2 block [[2,1],[1,2]] has eigenvalues 1 and 3; the
+	// decoupled z axis contributes a third eigenvalue of 3
+	m := [3][3]float64{{2, 1, 0}, {1, 2, 0}, {0, 0, 3}}
+
+	values, vectors := jacobiEigen3x3(m)
+
+	got := append([]float64{}, values[:]...)
+	sort.Float64s(got)
+	want := []float64{1, 3, 3}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("eigenvalues = %v, want %v", got, want)
+		}
+	}
+	checkEigenvectors(t, m, values, vectors)
+}
@@ -0,0 +1,102 @@
+package ransac
+
+import (
+	"math/rand"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+// defaultLocalityK is LocalitySampler's default neighbourhood size when K is
+// left unset
+const defaultLocalityK = 16
+
+// Sampler generates the stream of candidate triplets fed into the RANSAC
+// pipeline's plane estimation stage
+type Sampler interface {
+	Stream(points []pointcloud.Point3D, stop <-chan bool) <-chan [3]pointcloud.Point3D
+}
+
+// UniformSampler draws all three triplet points uniformly at random, with
+// no bias towards any region of the cloud. This is the package's original
+// sampling behaviour
+type UniformSampler struct {
+	Rng *rand.Rand // nil uses the global math/rand source
+}
+
+func (s UniformSampler) Stream(points []pointcloud.Point3D, stop <-chan bool) <-chan [3]pointcloud.Point3D {
+	randomPointStream := RandomPointGenerator(points, stop, s.Rng)
+	return TripletGenerator(randomPointStream)
+}
+
+// LocalitySampler implements PROSAC-style guided sampling: it draws the
+// first point uniformly at random, then draws the 2nd and 3rd points from
+// that point's K nearest neighbours (via a k-d tree built once, on first
+// use). This drastically improves the hit-rate for small planes in dense
+// clouds, where a uniformly-drawn triplet is unlikely to land on the same
+// small surface
+type LocalitySampler struct {
+	Rng *rand.Rand // nil uses the global math/rand source
+	K   int        // neighbourhood size for the 2nd/3rd points; defaults to 16
+}
+
+func (s LocalitySampler) Stream(points []pointcloud.Point3D, stop <-chan bool) <-chan [3]pointcloud.Point3D {
+	k := s.K
+	if k <= 0 {
+		k = defaultLocalityK
+	}
+	if k > len(points)-1 {
+		k = len(points) - 1
+	}
+
+	tree := newKDTree(points)
+	tripletStream := make(chan [3]pointcloud.Point3D)
+	go func() {
+		defer close(tripletStream)
+		n := len(points)
+		for {
+			// Checked every iteration (not just after a valid triplet is
+			// drawn below) so a duplicate-heavy/degenerate cloud that keeps
+			// failing the distinctness check still observes stop, rather
+			// than spinning forever
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			p1Index := nextIndex(s.Rng, n)
+			triplet := [3]pointcloud.Point3D{points[p1Index]}
+
+			neighbours := tree.kNearestNeighbours(points[p1Index], p1Index, k)
+			if len(neighbours) < 2 {
+				// Too few neighbours (tiny cloud) to draw a proper locality
+				// triplet; fall back to uniform sampling for this one
+				triplet[1] = points[nextIndex(s.Rng, n)]
+				triplet[2] = points[nextIndex(s.Rng, n)]
+			} else {
+				i1, i2 := pickTwoDistinct(s.Rng, len(neighbours))
+				triplet[1] = points[neighbours[i1]]
+				triplet[2] = points[neighbours[i2]]
+			}
+
+			if triplet[0] != triplet[1] && triplet[0] != triplet[2] && triplet[1] != triplet[2] {
+				select {
+				case <-stop:
+					return
+				case tripletStream <- triplet:
+				}
+			}
+		}
+	}()
+	return tripletStream
+}
+
+// pickTwoDistinct draws two distinct indices in [0, n)
+func pickTwoDistinct(rng *rand.Rand, n int) (int, int) {
+	i := nextIndex(rng, n)
+	j := nextIndex(rng, n)
+	for j == i {
+		j = nextIndex(rng, n)
+	}
+	return i, j
+}
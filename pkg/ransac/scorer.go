@@ -0,0 +1,158 @@
+package ransac
+
+import (
+	"math"
+
+	"github.com/yayosaleh/plane-ransac/pkg/pointcloud"
+)
+
+// mlesacEMIterations is the number of EM iterations MLESACScorer runs to
+// estimate the inlier ratio gamma
+const mlesacEMIterations = 5
+
+// Scorer assigns a cost to a candidate plane given a point cloud and the
+// inlier threshold eps; lower cost is better. This lets Run/RunWithOptions
+// rank candidate planes by something other than raw inlier count
+type Scorer interface {
+	Score(plane Plane3D, points []pointcloud.Point3D, eps float64) float64
+}
+
+// RANSACScorer reproduces the package's original behaviour: the plane with
+// the most inliers (distance < eps) wins. Costs are negated inlier counts
+// so that, like every other Scorer, lower is better
+type RANSACScorer struct{}
+
+func (RANSACScorer) Score(plane Plane3D, points []pointcloud.Point3D, eps float64) float64 {
+	return -float64(getSupportFast(plane, points, eps))
+}
+
+// MSACScorer scores a plane by the sum, over all points, of min(d², eps²) -
+// unlike RANSACScorer this doesn't saturate at eps, so it distinguishes a
+// tight-fitting plane from a loose one with the same inlier count
+type MSACScorer struct{}
+
+func (MSACScorer) Score(plane Plane3D, points []pointcloud.Point3D, eps float64) float64 {
+	A, B, C, D := plane.A, plane.B, plane.C, plane.D
+	norm := A*A + B*B + C*C
+	epsSq := eps * eps * norm // eps² scaled by norm to match the unnormalized numerator below
+
+	cost := 0.0
+	for _, pt := range points {
+		num := A*pt.X + B*pt.Y + C*pt.Z - D
+		dSq := num * num
+		if dSq < epsSq {
+			cost += dSq / norm
+		} else {
+			cost += epsSq / norm
+		}
+	}
+	return cost
+}
+
+// preparer is implemented by Scorers that need to precompute state shared
+// across every Score call in a run (e.g. a bounding box volume, which
+// doesn't depend on the candidate plane). RunWithOptions calls prepare once
+// before scoring begins, rather than paying the precomputation's cost on
+// every one of the (potentially 10^5-10^6) calls to Score
+type preparer interface {
+	prepare(points []pointcloud.Point3D) Scorer
+}
+
+// MLESACScorer models the residuals as a mixture of a Gaussian inlier
+// distribution (sigma = eps/2) and a uniform outlier distribution over the
+// cloud's bounding box, estimates the inlier ratio gamma via a handful of EM
+// iterations, and scores the plane by the resulting negative
+// log-likelihood (lower is better, as with the other scorers)
+type MLESACScorer struct{}
+
+func (MLESACScorer) Score(plane Plane3D, points []pointcloud.Point3D, eps float64) float64 {
+	return preparedMLESACScorer{volume: boundingBoxVolume(points)}.Score(plane, points, eps)
+}
+
+// prepare computes the cloud's bounding-box volume once so RunWithOptions
+// can reuse it across every candidate plane, instead of MLESACScorer
+// recomputing it (an O(n) pass) on every Score call
+func (MLESACScorer) prepare(points []pointcloud.Point3D) Scorer {
+	return preparedMLESACScorer{volume: boundingBoxVolume(points)}
+}
+
+// preparedMLESACScorer is MLESACScorer with its bounding-box volume already
+// computed, since it is the same for every candidate plane in a run
+type preparedMLESACScorer struct {
+	volume float64
+}
+
+func (s preparedMLESACScorer) Score(plane Plane3D, points []pointcloud.Point3D, eps float64) float64 {
+	n := len(points)
+	if n == 0 {
+		return 0
+	}
+
+	A, B, C, D := plane.A, plane.B, plane.C, plane.D
+	norm := math.Sqrt(A*A + B*B + C*C)
+	sigma := eps / 2
+	gaussCoeff := 1 / math.Sqrt(2*math.Pi*sigma*sigma)
+	outlierDensity := 1 / s.volume
+
+	dist := make([]float64, n)
+	gauss := make([]float64, n)
+	for i, pt := range points {
+		d := (A*pt.X + B*pt.Y + C*pt.Z - D) / norm
+		dist[i] = d
+		gauss[i] = gaussCoeff * math.Exp(-(d*d)/(2*sigma*sigma))
+	}
+
+	// EM: gamma <- mean(p_i), p_i = gamma*N(d_i|0,sigma) / (gamma*N(d_i|0,sigma) + (1-gamma)*outlierDensity)
+	gamma := 0.5
+	for iter := 0; iter < mlesacEMIterations; iter++ {
+		sum := 0.0
+		for i := range points {
+			inlierTerm := gamma * gauss[i]
+			sum += inlierTerm / (inlierTerm + (1-gamma)*outlierDensity)
+		}
+		gamma = sum / float64(n)
+	}
+
+	cost := 0.0
+	for i := range points {
+		likelihood := gamma*gauss[i] + (1-gamma)*outlierDensity
+		cost -= math.Log(likelihood)
+	}
+	return cost
+}
+
+// boundingBoxVolume returns the volume of the axis-aligned bounding box of
+// points
+func boundingBoxVolume(points []pointcloud.Point3D) float64 {
+	if len(points) == 0 {
+		return 1
+	}
+
+	min, max := points[0], points[0]
+	for _, pt := range points[1:] {
+		if pt.X < min.X {
+			min.X = pt.X
+		}
+		if pt.Y < min.Y {
+			min.Y = pt.Y
+		}
+		if pt.Z < min.Z {
+			min.Z = pt.Z
+		}
+		if pt.X > max.X {
+			max.X = pt.X
+		}
+		if pt.Y > max.Y {
+			max.Y = pt.Y
+		}
+		if pt.Z > max.Z {
+			max.Z = pt.Z
+		}
+	}
+
+	volume := (max.X - min.X) * (max.Y - min.Y) * (max.Z - min.Z)
+	if volume <= 0 {
+		return 1
+	}
+	return volume
+}